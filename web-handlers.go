@@ -17,13 +17,26 @@
 package main
 
 import (
+	"crypto/hmac"
+	"crypto/md5"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	jwtgo "github.com/dgrijalva/jwt-go"
@@ -137,6 +150,192 @@ func (web *webAPI) MakeBucket(r *http.Request, args *MakeBucketArgs, reply *Gene
 	return nil
 }
 
+// PolicyStatement - a single statement within a bucket access policy
+// document, modeled after the S3 bucket policy language.
+type PolicyStatement struct {
+	Effect    string   `json:"Effect"`
+	Principal string   `json:"Principal"`
+	Action    []string `json:"Action"`
+	Resource  []string `json:"Resource"`
+}
+
+// BucketAccessPolicy - the bucket policy document persisted at
+// .minio/<bucket>/policy.json.
+type BucketAccessPolicy struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// bucketPolicyFile - filename a bucket's policy document is stored
+// under, inside its .minio/<bucket>/ metadata directory.
+const bucketPolicyFile = "policy.json"
+
+func bucketPolicyPath(bucketName string) string {
+	return filepath.Join(minioMetaBucket, bucketName, bucketPolicyFile)
+}
+
+func readBucketPolicy(bucketName string) (BucketAccessPolicy, error) {
+	var policy BucketAccessPolicy
+	data, e := ioutil.ReadFile(bucketPolicyPath(bucketName))
+	if e != nil {
+		return policy, e
+	}
+	e = json.Unmarshal(data, &policy)
+	return policy, e
+}
+
+func writeBucketPolicy(bucketName string, policy BucketAccessPolicy) error {
+	policyPath := bucketPolicyPath(bucketName)
+	if e := os.MkdirAll(filepath.Dir(policyPath), 0700); e != nil {
+		return e
+	}
+	data, e := json.Marshal(policy)
+	if e != nil {
+		return e
+	}
+	return ioutil.WriteFile(policyPath, data, 0600)
+}
+
+// isAnonymousActionAllowed reports whether bucketName's persisted policy
+// grants the given unauthenticated action (e.g. "s3:GetObject") to
+// Principal "*". Only wired into the browser's Download handler below;
+// the S3 REST API handlers live outside this file and are untouched here.
+func isAnonymousActionAllowed(bucketName, action string) bool {
+	policy, e := readBucketPolicy(bucketName)
+	if e != nil {
+		return false
+	}
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" || statement.Principal != "*" {
+			continue
+		}
+		for _, a := range statement.Action {
+			if a == action {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetBucketPolicyArgs - get bucket policy args.
+type GetBucketPolicyArgs struct {
+	BucketName string `json:"bucketName"`
+}
+
+// GetBucketPolicyRep - get bucket policy reply.
+type GetBucketPolicyRep struct {
+	Policy    BucketAccessPolicy `json:"policy"`
+	UIVersion string             `json:"uiVersion"`
+}
+
+// GetBucketPolicy - returns the access policy document for a bucket, or
+// an empty (private) policy if none has been set yet.
+func (web *webAPI) GetBucketPolicy(r *http.Request, args *GetBucketPolicyArgs, reply *GetBucketPolicyRep) error {
+	if !isJWTReqAuthenticated(r) {
+		return &json2.Error{Message: "Unauthorized request"}
+	}
+	if !fs.IsValidBucketName(args.BucketName) {
+		return &json2.Error{Message: getAPIError(ErrInvalidBucketName).Description}
+	}
+	policy, e := readBucketPolicy(args.BucketName)
+	if e != nil && !os.IsNotExist(e) {
+		return &json2.Error{Message: e.Error()}
+	}
+	reply.Policy = policy
+	reply.UIVersion = miniobrowser.UIVersion
+	return nil
+}
+
+// SetBucketPolicyArgs - set bucket policy args.
+type SetBucketPolicyArgs struct {
+	BucketName string             `json:"bucketName"`
+	Policy     BucketAccessPolicy `json:"policy"`
+}
+
+// SetBucketPolicy - persists an access policy document for a bucket.
+func (web *webAPI) SetBucketPolicy(r *http.Request, args *SetBucketPolicyArgs, reply *GenericRep) error {
+	if !isJWTReqAuthenticated(r) {
+		return &json2.Error{Message: "Unauthorized request"}
+	}
+	if !fs.IsValidBucketName(args.BucketName) {
+		return &json2.Error{Message: getAPIError(ErrInvalidBucketName).Description}
+	}
+	if e := writeBucketPolicy(args.BucketName, args.Policy); e != nil {
+		return &json2.Error{Message: e.Error()}
+	}
+	reply.UIVersion = miniobrowser.UIVersion
+	return nil
+}
+
+// bucketAccessType - coarse bucket access levels the UI exposes as a
+// single "share this bucket" toggle instead of a raw policy document.
+type bucketAccessType string
+
+const (
+	bucketAccessPrivate  bucketAccessType = "private"
+	bucketAccessReadOnly bucketAccessType = "readonly"
+	bucketAccessPublic   bucketAccessType = "public"
+)
+
+// policyForAccess builds the policy document a coarse access level maps
+// to for the given bucket.
+func policyForAccess(bucketName string, access bucketAccessType) (BucketAccessPolicy, error) {
+	resource := "arn:aws:s3:::" + bucketName
+	switch access {
+	case bucketAccessPrivate:
+		return BucketAccessPolicy{Version: "2012-10-17"}, nil
+	case bucketAccessReadOnly:
+		return BucketAccessPolicy{
+			Version: "2012-10-17",
+			Statement: []PolicyStatement{{
+				Effect:    "Allow",
+				Principal: "*",
+				Action:    []string{"s3:GetObject", "s3:ListBucket"},
+				Resource:  []string{resource, resource + "/*"},
+			}},
+		}, nil
+	case bucketAccessPublic:
+		return BucketAccessPolicy{
+			Version: "2012-10-17",
+			Statement: []PolicyStatement{{
+				Effect:    "Allow",
+				Principal: "*",
+				Action:    []string{"s3:GetObject", "s3:ListBucket", "s3:PutObject"},
+				Resource:  []string{resource, resource + "/*"},
+			}},
+		}, nil
+	default:
+		return BucketAccessPolicy{}, fmt.Errorf("unknown bucket access %q", access)
+	}
+}
+
+// SetBucketAccessArgs - set bucket access args.
+type SetBucketAccessArgs struct {
+	BucketName string `json:"bucketName"`
+	Access     string `json:"access"`
+}
+
+// SetBucketAccess - a convenience wrapper around SetBucketPolicy taking
+// a coarse private/readonly/public access level instead of a raw policy.
+func (web *webAPI) SetBucketAccess(r *http.Request, args *SetBucketAccessArgs, reply *GenericRep) error {
+	if !isJWTReqAuthenticated(r) {
+		return &json2.Error{Message: "Unauthorized request"}
+	}
+	if !fs.IsValidBucketName(args.BucketName) {
+		return &json2.Error{Message: getAPIError(ErrInvalidBucketName).Description}
+	}
+	policy, e := policyForAccess(args.BucketName, bucketAccessType(args.Access))
+	if e != nil {
+		return &json2.Error{Message: e.Error()}
+	}
+	if e = writeBucketPolicy(args.BucketName, policy); e != nil {
+		return &json2.Error{Message: e.Error()}
+	}
+	reply.UIVersion = miniobrowser.UIVersion
+	return nil
+}
+
 // ListBucketsRep - list buckets response
 type ListBucketsRep struct {
 	Buckets   []BucketInfo `json:"buckets"`
@@ -173,16 +372,26 @@ func (web *webAPI) ListBuckets(r *http.Request, args *GenericArgs, reply *ListBu
 	return nil
 }
 
-// ListObjectsArgs - list object args.
+// listObjectsMaxKeys - largest page size a single ListObjects call returns.
+const listObjectsMaxKeys = 1000
+
+// ListObjectsArgs - paginated list objects args.
 type ListObjectsArgs struct {
 	BucketName string `json:"bucketName"`
 	Prefix     string `json:"prefix"`
+	Delimiter  string `json:"delimiter"`
+	Marker     string `json:"marker"`
+	MaxKeys    int    `json:"maxKeys"`
+	Recursive  bool   `json:"recursive"`
 }
 
-// ListObjectsRep - list objects response.
+// ListObjectsRep - paginated list objects response.
 type ListObjectsRep struct {
-	Objects   []ObjectInfo `json:"objects"`
-	UIVersion string       `json:"uiVersion"`
+	Objects     []ObjectInfo `json:"objects"`
+	Prefixes    []string     `json:"prefixes"`
+	NextMarker  string       `json:"nextMarker"`
+	IsTruncated bool         `json:"isTruncated"`
+	UIVersion   string       `json:"uiVersion"`
 }
 
 // ObjectInfo container for list objects metadata.
@@ -197,36 +406,104 @@ type ObjectInfo struct {
 	ContentType string `json:"contentType"`
 }
 
-// ListObjects - list objects api.
+// ListObjects - returns a single page of objects and common prefixes; the
+// caller passes the returned marker back in to fetch the next page.
 func (web *webAPI) ListObjects(r *http.Request, args *ListObjectsArgs, reply *ListObjectsRep) error {
-	marker := ""
 	if !isJWTReqAuthenticated(r) {
 		return &json2.Error{Message: "Unauthorized request"}
 	}
+	maxKeys := args.MaxKeys
+	if maxKeys <= 0 || maxKeys > listObjectsMaxKeys {
+		maxKeys = listObjectsMaxKeys
+	}
+	delimiter := args.Delimiter
+	if args.Recursive {
+		delimiter = ""
+	} else if delimiter == "" {
+		delimiter = "/"
+	}
+
+	lo, err := web.Filesystem.ListObjects(args.BucketName, args.Prefix, args.Marker, delimiter, maxKeys)
+	if err != nil {
+		return &json2.Error{Message: err.Cause.Error()}
+	}
+	for _, obj := range lo.Objects {
+		reply.Objects = append(reply.Objects, ObjectInfo{
+			Key:          obj.Name,
+			LastModified: obj.ModifiedTime,
+			Size:         obj.Size,
+		})
+	}
+	reply.Prefixes = lo.Prefixes
+	reply.NextMarker = lo.NextMarker
+	reply.IsTruncated = lo.IsTruncated
+	reply.UIVersion = miniobrowser.UIVersion
+	return nil
+}
+
+// ListObjectsStream - Server-Sent-Events endpoint that streams objects
+// and prefixes as the filesystem walk produces them.
+func (web *webAPI) ListObjectsStream(w http.ResponseWriter, r *http.Request) {
+	if !isJWTReqAuthenticated(r) {
+		writeWebErrorResponse(w, errInvalidToken)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeWebErrorResponse(w, fmt.Errorf("streaming unsupported"))
+		return
+	}
+
+	query := r.URL.Query()
+	bucketName := query.Get("bucketName")
+	prefix := query.Get("prefix")
+	delimiter := query.Get("delimiter")
+	if query.Get("recursive") == "true" {
+		delimiter = ""
+	} else if delimiter == "" {
+		delimiter = "/"
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	marker := ""
+	ctx := r.Context()
 	for {
-		lo, err := web.Filesystem.ListObjects(args.BucketName, args.Prefix, marker, "/", 1000)
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		lo, err := web.Filesystem.ListObjects(bucketName, prefix, marker, delimiter, listObjectsMaxKeys)
 		if err != nil {
-			return &json2.Error{Message: err.Cause.Error()}
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", err.Cause.Error())
+			flusher.Flush()
+			return
 		}
-		marker = lo.NextMarker
 		for _, obj := range lo.Objects {
-			reply.Objects = append(reply.Objects, ObjectInfo{
+			data, e := json.Marshal(ObjectInfo{
 				Key:          obj.Name,
 				LastModified: obj.ModifiedTime,
 				Size:         obj.Size,
 			})
+			if e != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: object\ndata: %s\n\n", data)
 		}
-		for _, prefix := range lo.Prefixes {
-			reply.Objects = append(reply.Objects, ObjectInfo{
-				Key: prefix,
-			})
+		for _, p := range lo.Prefixes {
+			fmt.Fprintf(w, "event: prefix\ndata: %s\n\n", p)
 		}
+		flusher.Flush()
 		if !lo.IsTruncated {
 			break
 		}
+		marker = lo.NextMarker
 	}
-	reply.UIVersion = miniobrowser.UIVersion
-	return nil
+	fmt.Fprint(w, "event: done\ndata: {}\n\n")
+	flusher.Flush()
 }
 
 // RemoveObjectArgs - args to remove an object
@@ -249,6 +526,83 @@ func (web *webAPI) RemoveObject(r *http.Request, args *RemoveObjectArgs, reply *
 	return nil
 }
 
+// RemoveObjectsArgs - args to remove a batch of objects in one call.
+type RemoveObjectsArgs struct {
+	BucketName string   `json:"bucketName"`
+	Objects    []string `json:"objects"`
+}
+
+// RemoveObjectResult - per-object outcome of a RemoveObjects call.
+type RemoveObjectResult struct {
+	ObjectName string `json:"objectName"`
+	Error      string `json:"error,omitempty"`
+}
+
+// RemoveObjectsRep - batch remove objects reply.
+type RemoveObjectsRep struct {
+	Objects   []RemoveObjectResult `json:"objects"`
+	UIVersion string               `json:"uiVersion"`
+}
+
+// removeObjectsWorkers - number of goroutines used to delete objects concurrently.
+const removeObjectsWorkers = 16
+
+// RemoveObjects - removes a batch of objects, reporting a per-object result.
+func (web *webAPI) RemoveObjects(r *http.Request, args *RemoveObjectsArgs, reply *RemoveObjectsRep) error {
+	if !isJWTReqAuthenticated(r) {
+		return &json2.Error{Message: "Unauthorized request"}
+	}
+	reply.UIVersion = miniobrowser.UIVersion
+	reply.Objects = make([]RemoveObjectResult, len(args.Objects))
+
+	indexCh := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < removeObjectsWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexCh {
+				objectName := args.Objects[idx]
+				result := RemoveObjectResult{ObjectName: objectName}
+				if e := web.Filesystem.DeleteObject(args.BucketName, objectName); e != nil {
+					result.Error = webAPIErrorCode(e.ToGoError())
+				}
+				reply.Objects[idx] = result
+			}
+		}()
+	}
+	for idx := range args.Objects {
+		indexCh <- idx
+	}
+	close(indexCh)
+	wg.Wait()
+	return nil
+}
+
+// webAPIErrorCode maps an fs error to the error code writeWebErrorResponse uses for it.
+func webAPIErrorCode(err error) string {
+	switch err.(type) {
+	case fs.BucketNotFound:
+		return getAPIError(ErrNoSuchBucket).Code
+	case fs.BucketNameInvalid:
+		return getAPIError(ErrInvalidBucketName).Code
+	case fs.ObjectNotFound:
+		return getAPIError(ErrNoSuchKey).Code
+	case fs.ObjectNameInvalid:
+		return getAPIError(ErrNoSuchKey).Code
+	case fs.RootPathFull:
+		return getAPIError(ErrRootPathFull).Code
+	case fs.BadDigest:
+		return getAPIError(ErrBadDigest).Code
+	case fs.IncompleteBody:
+		return getAPIError(ErrIncompleteBody).Code
+	case fs.ObjectExistsAsPrefix:
+		return getAPIError(ErrObjectExistsAsPrefix).Code
+	default:
+		return getAPIError(ErrInternalError).Code
+	}
+}
+
 // LoginArgs - login arguments.
 type LoginArgs struct {
 	Username string `json:"username" form:"username"`
@@ -355,6 +709,168 @@ func (web *webAPI) GetAuth(r *http.Request, args *GenericArgs, reply *GetAuthRep
 	return nil
 }
 
+const (
+	// presignedURLExpirationMin - shortest expiry a presigned URL may request.
+	presignedURLExpirationMin = 1 * time.Second
+	// presignedURLExpirationMax - longest expiry a presigned URL may request,
+	// matching the 7 day ceiling AWS SigV4 imposes on query-string auth.
+	presignedURLExpirationMax = 7 * 24 * time.Hour
+	// presignedURLDefaultExpiry - expiry used when the caller doesn't set one.
+	presignedURLDefaultExpiry = 1 * time.Hour
+)
+
+// PresignedGetObjectArgs - presigned GET request args.
+type PresignedGetObjectArgs struct {
+	BucketName     string `json:"bucketName"`
+	ObjectName     string `json:"objectName"`
+	ExpiresSeconds int64  `json:"expiresSeconds"`
+}
+
+// PresignedPutObjectArgs - presigned PUT request args.
+type PresignedPutObjectArgs struct {
+	BucketName     string `json:"bucketName"`
+	ObjectName     string `json:"objectName"`
+	ExpiresSeconds int64  `json:"expiresSeconds"`
+}
+
+// PresignedObjectRep - presigned URL reply.
+type PresignedObjectRep struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	UIVersion string    `json:"uiVersion"`
+}
+
+// PresignedGetObject - generates a presigned GET URL for an object.
+func (web *webAPI) PresignedGetObject(r *http.Request, args *PresignedGetObjectArgs, reply *PresignedObjectRep) error {
+	if !isJWTReqAuthenticated(r) {
+		return &json2.Error{Message: "Unauthorized request"}
+	}
+	presignedURL, expiresAt, e := presignObjectURL(r, http.MethodGet, args.BucketName, args.ObjectName, args.ExpiresSeconds)
+	if e != nil {
+		return &json2.Error{Message: e.Error()}
+	}
+	reply.URL = presignedURL
+	reply.ExpiresAt = expiresAt
+	reply.UIVersion = miniobrowser.UIVersion
+	return nil
+}
+
+// PresignedPutObject - generates a presigned PUT URL for an object.
+func (web *webAPI) PresignedPutObject(r *http.Request, args *PresignedPutObjectArgs, reply *PresignedObjectRep) error {
+	if !isJWTReqAuthenticated(r) {
+		return &json2.Error{Message: "Unauthorized request"}
+	}
+	presignedURL, expiresAt, e := presignObjectURL(r, http.MethodPut, args.BucketName, args.ObjectName, args.ExpiresSeconds)
+	if e != nil {
+		return &json2.Error{Message: e.Error()}
+	}
+	reply.URL = presignedURL
+	reply.ExpiresAt = expiresAt
+	reply.UIVersion = miniobrowser.UIVersion
+	return nil
+}
+
+// presignObjectURL signs a GET/PUT request to bucket/object with SigV4
+// query-string authentication.
+func presignObjectURL(r *http.Request, method, bucketName, objectName string, expiresSeconds int64) (string, time.Time, error) {
+	if expiresSeconds <= 0 {
+		expiresSeconds = int64(presignedURLDefaultExpiry.Seconds())
+	}
+	expires := time.Duration(expiresSeconds) * time.Second
+	if expires < presignedURLExpirationMin || expires > presignedURLExpirationMax {
+		return "", time.Time{}, fmt.Errorf("expiresSeconds must be between %d and %d",
+			int(presignedURLExpirationMin.Seconds()), int(presignedURLExpirationMax.Seconds()))
+	}
+
+	cred := serverConfig.GetCredential()
+	region := serverConfig.GetRegion()
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", dateStamp, region)
+
+	host := r.Host
+	uri := path.Join("/", encodeURIPath(bucketName), encodeURIPath(objectName))
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", fmt.Sprintf("%s/%s", cred.AccessKeyID, scope))
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(expires.Seconds()), 10))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		uri,
+		canonicalQueryString(query),
+		"host:" + host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(sum256([]byte(canonicalRequest))),
+	}, "\n")
+
+	signingKey := signingKey(cred.SecretAccessKey, dateStamp, region)
+	query.Set("X-Amz-Signature", hex.EncodeToString(hmacSHA256(signingKey, stringToSign)))
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s%s?%s", scheme, host, uri, query.Encode()), now.Add(expires), nil
+}
+
+// encodeURIPath percent-encodes each "/"-separated segment of p.
+func encodeURIPath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQueryString returns the SigV4 canonical query string: keys
+// sorted and percent-encoded per RFC 3986.
+func canonicalQueryString(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, strings.Replace(url.QueryEscape(k), "+", "%20", -1)+"="+
+			strings.Replace(url.QueryEscape(query.Get(k)), "+", "%20", -1))
+	}
+	return strings.Join(parts, "&")
+}
+
+func sum256(data []byte) []byte {
+	hash := sha256.New()
+	hash.Write(data)
+	return hash.Sum(nil)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	hash := hmac.New(sha256.New, key)
+	hash.Write([]byte(data))
+	return hash.Sum(nil)
+}
+
+// signingKey derives the SigV4 signing key from the secret key, date,
+// and region following the AWS4-HMAC-SHA256 key derivation chain.
+func signingKey(secretKey, dateStamp, region string) []byte {
+	dateKey := hmacSHA256([]byte("AWS4"+secretKey), dateStamp)
+	dateRegionKey := hmacSHA256(dateKey, region)
+	dateRegionServiceKey := hmacSHA256(dateRegionKey, "s3")
+	return hmacSHA256(dateRegionServiceKey, "aws4_request")
+}
+
 // Upload - file upload handler.
 func (web *webAPI) Upload(w http.ResponseWriter, r *http.Request) {
 	if !isJWTReqAuthenticated(r) {
@@ -369,13 +885,412 @@ func (web *webAPI) Upload(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Download - file download handler.
+const (
+	// minioMetaBucket - directory minio stores its own bookkeeping under.
+	// Paths built from it are relative, so they resolve against the server
+	// process's working directory, not the filesystem root holding buckets.
+	minioMetaBucket = ".minio"
+	// multipartMetaDir - directory under minioMetaBucket parts are
+	// staged in until CompleteMultipartUpload concatenates them.
+	multipartMetaDir = "multipart"
+)
+
+// uploadIDPattern - the 32 hex-char shape generateUploadID produces.
+var uploadIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+func isValidUploadID(uploadID string) bool {
+	return uploadIDPattern.MatchString(uploadID)
+}
+
+// multipartStagingPath returns the directory a given upload's parts are
+// written to, .minio/multipart/<uploadID>; each part is staged inside it
+// as part.00001, part.00002, and so on.
+func multipartStagingPath(uploadID string) string {
+	return filepath.Join(minioMetaBucket, multipartMetaDir, uploadID)
+}
+
+func multipartPartPath(uploadID string, partNumber int) string {
+	return filepath.Join(multipartStagingPath(uploadID), fmt.Sprintf("part.%05d", partNumber))
+}
+
+// multipartUploadMetaFile - filename holding the bucket/object an
+// uploadID was opened against, alongside its staged parts.
+const multipartUploadMetaFile = "upload.json"
+
+func multipartMetaPath(uploadID string) string {
+	return filepath.Join(multipartStagingPath(uploadID), multipartUploadMetaFile)
+}
+
+// multipartUploadMeta - the bucket/object an upload ID was opened against.
+type multipartUploadMeta struct {
+	BucketName string `json:"bucketName"`
+	ObjectName string `json:"objectName"`
+}
+
+func writeMultipartUploadMeta(uploadID, bucketName, objectName string) error {
+	data, e := json.Marshal(multipartUploadMeta{BucketName: bucketName, ObjectName: objectName})
+	if e != nil {
+		return e
+	}
+	return ioutil.WriteFile(multipartMetaPath(uploadID), data, 0600)
+}
+
+func readMultipartUploadMeta(uploadID string) (multipartUploadMeta, error) {
+	var meta multipartUploadMeta
+	data, e := ioutil.ReadFile(multipartMetaPath(uploadID))
+	if e != nil {
+		return meta, e
+	}
+	e = json.Unmarshal(data, &meta)
+	return meta, e
+}
+
+// NewMultipartUploadArgs - start a new multipart upload args.
+type NewMultipartUploadArgs struct {
+	BucketName string `json:"bucketName"`
+	ObjectName string `json:"objectName"`
+}
+
+// NewMultipartUploadRep - new multipart upload reply.
+type NewMultipartUploadRep struct {
+	UploadID  string `json:"uploadID"`
+	UIVersion string `json:"uiVersion"`
+}
+
+// NewMultipartUpload - initiates a resumable multipart upload and returns an uploadID.
+func (web *webAPI) NewMultipartUpload(r *http.Request, args *NewMultipartUploadArgs, reply *NewMultipartUploadRep) error {
+	if !isJWTReqAuthenticated(r) {
+		return &json2.Error{Message: "Unauthorized request"}
+	}
+	uploadID, e := generateUploadID()
+	if e != nil {
+		return &json2.Error{Message: e.Error()}
+	}
+	if e = os.MkdirAll(multipartStagingPath(uploadID), 0700); e != nil {
+		return &json2.Error{Message: e.Error()}
+	}
+	if e = writeMultipartUploadMeta(uploadID, args.BucketName, args.ObjectName); e != nil {
+		return &json2.Error{Message: e.Error()}
+	}
+	reply.UploadID = uploadID
+	reply.UIVersion = miniobrowser.UIVersion
+	return nil
+}
+
+// generateUploadID returns a random hex upload identifier.
+func generateUploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, e := cryptorand.Read(b); e != nil {
+		return "", e
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// UploadPart - streams a single part into the upload's staging area, returning its ETag.
+func (web *webAPI) UploadPart(w http.ResponseWriter, r *http.Request) {
+	if !isJWTReqAuthenticated(r) {
+		writeWebErrorResponse(w, errInvalidToken)
+		return
+	}
+	vars := mux.Vars(r)
+	uploadID := vars["uploadID"]
+	if !isValidUploadID(uploadID) {
+		writeWebErrorResponse(w, fmt.Errorf("invalid upload ID"))
+		return
+	}
+	partNumber, e := strconv.Atoi(vars["partNumber"])
+	if e != nil || partNumber <= 0 {
+		writeWebErrorResponse(w, fmt.Errorf("invalid part number"))
+		return
+	}
+	partFile, e := os.Create(multipartPartPath(uploadID, partNumber))
+	if e != nil {
+		writeWebErrorResponse(w, e)
+		return
+	}
+	defer partFile.Close()
+
+	hasher := md5.New()
+	if _, e = io.Copy(io.MultiWriter(partFile, hasher), r.Body); e != nil {
+		writeWebErrorResponse(w, e)
+		return
+	}
+	etag := hex.EncodeToString(hasher.Sum(nil))
+	w.Header().Set("ETag", etag)
+	w.Write([]byte(etag))
+}
+
+// CompletePart - a single part referenced by CompleteMultipartUpload.
+type CompletePart struct {
+	PartNumber int    `json:"partNumber"`
+	ETag       string `json:"etag"`
+}
+
+// CompleteMultipartUploadArgs - complete multipart upload args.
+type CompleteMultipartUploadArgs struct {
+	BucketName string         `json:"bucketName"`
+	ObjectName string         `json:"objectName"`
+	UploadID   string         `json:"uploadID"`
+	Parts      []CompletePart `json:"parts"`
+}
+
+// CompleteMultipartUploadRep - complete multipart upload reply.
+type CompleteMultipartUploadRep struct {
+	ETag      string `json:"etag"`
+	UIVersion string `json:"uiVersion"`
+}
+
+// CompleteMultipartUpload - verifies every part's ETag, then concatenates
+// the staged parts in order into the final object.
+func (web *webAPI) CompleteMultipartUpload(r *http.Request, args *CompleteMultipartUploadArgs, reply *CompleteMultipartUploadRep) error {
+	if !isJWTReqAuthenticated(r) {
+		return &json2.Error{Message: "Unauthorized request"}
+	}
+	if !isValidUploadID(args.UploadID) {
+		return &json2.Error{Message: "invalid upload ID"}
+	}
+	meta, e := readMultipartUploadMeta(args.UploadID)
+	if e != nil {
+		return &json2.Error{Message: e.Error()}
+	}
+	if meta.BucketName != args.BucketName || meta.ObjectName != args.ObjectName {
+		return &json2.Error{Message: "upload ID does not belong to this bucket/object"}
+	}
+	parts := make([]CompletePart, len(args.Parts))
+	copy(parts, args.Parts)
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	for i, part := range parts {
+		if i > 0 && part.PartNumber == parts[i-1].PartNumber {
+			return &json2.Error{Message: fmt.Sprintf("duplicate part number %d", part.PartNumber)}
+		}
+		if part.PartNumber != i+1 {
+			return &json2.Error{Message: fmt.Sprintf("missing part number %d", i+1)}
+		}
+	}
+
+	stagingPath := multipartStagingPath(args.UploadID)
+	files := make([]*os.File, len(parts))
+	defer func() {
+		for _, f := range files {
+			if f != nil {
+				f.Close()
+			}
+		}
+	}()
+
+	readers := make([]io.Reader, len(parts))
+	for i, part := range parts {
+		f, e := os.Open(multipartPartPath(args.UploadID, part.PartNumber))
+		if e != nil {
+			return &json2.Error{Message: e.Error()}
+		}
+		files[i] = f
+
+		hasher := md5.New()
+		if _, e = io.Copy(hasher, f); e != nil {
+			return &json2.Error{Message: e.Error()}
+		}
+		if hex.EncodeToString(hasher.Sum(nil)) != part.ETag {
+			return &json2.Error{Message: fmt.Sprintf("etag mismatch for part %d", part.PartNumber)}
+		}
+		if _, e = f.Seek(0, io.SeekStart); e != nil {
+			return &json2.Error{Message: e.Error()}
+		}
+		readers[i] = f
+	}
+
+	hasher := md5.New()
+	reader := io.TeeReader(io.MultiReader(readers...), hasher)
+	if _, e := web.Filesystem.CreateObject(args.BucketName, args.ObjectName, -1, reader, nil); e != nil {
+		return &json2.Error{Message: e.ToGoError().Error()}
+	}
+	os.RemoveAll(stagingPath)
+
+	reply.ETag = hex.EncodeToString(hasher.Sum(nil))
+	reply.UIVersion = miniobrowser.UIVersion
+	return nil
+}
+
+// ListPartsArgs - list parts args, used to resume an interrupted
+// multipart upload.
+type ListPartsArgs struct {
+	BucketName string `json:"bucketName"`
+	ObjectName string `json:"objectName"`
+	UploadID   string `json:"uploadID"`
+}
+
+// ListPartsRep - list parts reply.
+type ListPartsRep struct {
+	Parts     []PartInfo `json:"parts"`
+	UIVersion string     `json:"uiVersion"`
+}
+
+// PartInfo - metadata of a single part already staged for an upload.
+type PartInfo struct {
+	PartNumber   int       `json:"partNumber"`
+	ETag         string    `json:"etag"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// ListParts - lists the parts already staged for an uploadID.
+func (web *webAPI) ListParts(r *http.Request, args *ListPartsArgs, reply *ListPartsRep) error {
+	if !isJWTReqAuthenticated(r) {
+		return &json2.Error{Message: "Unauthorized request"}
+	}
+	if !isValidUploadID(args.UploadID) {
+		return &json2.Error{Message: "invalid upload ID"}
+	}
+	entries, e := ioutil.ReadDir(multipartStagingPath(args.UploadID))
+	if e != nil {
+		if os.IsNotExist(e) {
+			reply.UIVersion = miniobrowser.UIVersion
+			return nil
+		}
+		return &json2.Error{Message: e.Error()}
+	}
+	for _, entry := range entries {
+		var partNumber int
+		if _, e = fmt.Sscanf(entry.Name(), "part.%05d", &partNumber); e != nil {
+			continue
+		}
+		f, e := os.Open(filepath.Join(multipartStagingPath(args.UploadID), entry.Name()))
+		if e != nil {
+			return &json2.Error{Message: e.Error()}
+		}
+		hasher := md5.New()
+		_, e = io.Copy(hasher, f)
+		f.Close()
+		if e != nil {
+			return &json2.Error{Message: e.Error()}
+		}
+		reply.Parts = append(reply.Parts, PartInfo{
+			PartNumber:   partNumber,
+			ETag:         hex.EncodeToString(hasher.Sum(nil)),
+			Size:         entry.Size(),
+			LastModified: entry.ModTime(),
+		})
+	}
+	reply.UIVersion = miniobrowser.UIVersion
+	return nil
+}
+
+// StatObjectArgs - stat object args.
+type StatObjectArgs struct {
+	BucketName string `json:"bucketName"`
+	ObjectName string `json:"objectName"`
+}
+
+// StatObjectRep - stat object reply.
+type StatObjectRep struct {
+	Size         int64             `json:"size"`
+	LastModified time.Time         `json:"lastModified"`
+	ETag         string            `json:"etag"`
+	ContentType  string            `json:"contentType"`
+	UserMetadata map[string]string `json:"userMetadata"`
+	UIVersion    string            `json:"uiVersion"`
+}
+
+// StatObject - returns object metadata without transferring its contents.
+// Relies on fs.Filesystem.StatObject, which is assumed to already exist
+// upstream; this series does not add it to the fs package.
+func (web *webAPI) StatObject(r *http.Request, args *StatObjectArgs, reply *StatObjectRep) error {
+	if !isJWTReqAuthenticated(r) {
+		return &json2.Error{Message: "Unauthorized request"}
+	}
+	objInfo, e := web.Filesystem.StatObject(args.BucketName, args.ObjectName)
+	if e != nil {
+		return &json2.Error{Message: e.Cause.Error()}
+	}
+	reply.Size = objInfo.Size
+	reply.LastModified = objInfo.ModifiedTime
+	reply.ETag = objInfo.MD5Sum
+	reply.ContentType = objInfo.ContentType
+	reply.UserMetadata = objInfo.UserDefined
+	reply.UIVersion = miniobrowser.UIVersion
+	return nil
+}
+
+// httpRange - a parsed single-range HTTP Range request.
+type httpRange struct {
+	offset, length int64
+}
+
+func (hr httpRange) contentRange(resourceSize int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", hr.offset, hr.offset+hr.length-1, resourceSize)
+}
+
+// parseRequestRange parses a single "bytes=start-end" Range header value
+// against an object of the given size. Suffix and open-ended ranges are
+// supported; multi-range requests are rejected.
+func parseRequestRange(rangeHeader string, resourceSize int64) (httpRange, error) {
+	const prefix = "bytes="
+	if !strings.HasPrefix(rangeHeader, prefix) {
+		return httpRange{}, fmt.Errorf("invalid range header %q", rangeHeader)
+	}
+	spec := strings.TrimPrefix(rangeHeader, prefix)
+	if strings.Contains(spec, ",") {
+		return httpRange{}, fmt.Errorf("multi-range requests are not supported")
+	}
+	parts := strings.SplitN(spec, "-", 2)
+	if len(parts) != 2 {
+		return httpRange{}, fmt.Errorf("invalid range header %q", rangeHeader)
+	}
+	if parts[0] == "" {
+		suffixLength, e := strconv.ParseInt(parts[1], 10, 64)
+		if e != nil || suffixLength <= 0 {
+			return httpRange{}, fmt.Errorf("invalid range header %q", rangeHeader)
+		}
+		if suffixLength > resourceSize {
+			suffixLength = resourceSize
+		}
+		return httpRange{offset: resourceSize - suffixLength, length: suffixLength}, nil
+	}
+	start, e := strconv.ParseInt(parts[0], 10, 64)
+	if e != nil || start < 0 || start >= resourceSize {
+		return httpRange{}, fmt.Errorf("invalid range header %q", rangeHeader)
+	}
+	end := resourceSize - 1
+	if parts[1] != "" {
+		end, e = strconv.ParseInt(parts[1], 10, 64)
+		if e != nil || end < start {
+			return httpRange{}, fmt.Errorf("invalid range header %q", rangeHeader)
+		}
+		if end >= resourceSize {
+			end = resourceSize - 1
+		}
+	}
+	return httpRange{offset: start, length: end - start + 1}, nil
+}
+
+// headerDeferredWriter delays WriteHeader until the first Write.
+type headerDeferredWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+}
+
+func (hw *headerDeferredWriter) Write(p []byte) (int, error) {
+	if !hw.wroteHeader {
+		hw.ResponseWriter.WriteHeader(hw.statusCode)
+		hw.wroteHeader = true
+	}
+	return hw.ResponseWriter.Write(p)
+}
+
+// Download - file download handler. Honors the HTTP Range header and
+// falls back to the bucket's policy for requests without a valid JWT.
 func (web *webAPI) Download(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
 	object := vars["object"]
 	token := r.URL.Query().Get("token")
 
+	if !fs.IsValidBucketName(bucket) {
+		writeWebErrorResponse(w, fs.BucketNameInvalid{Bucket: bucket})
+		return
+	}
+
 	jwt := initJWT()
 	jwttoken, e := jwtgo.Parse(token, func(token *jwtgo.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwtgo.SigningMethodHMAC); !ok {
@@ -383,13 +1298,38 @@ func (web *webAPI) Download(w http.ResponseWriter, r *http.Request) {
 		}
 		return []byte(jwt.SecretAccessKey), nil
 	})
-	if e != nil || !jwttoken.Valid {
+	if (e != nil || !jwttoken.Valid) && !isAnonymousActionAllowed(bucket, "s3:GetObject") {
 		writeWebErrorResponse(w, errInvalidToken)
 		return
 	}
+
+	objInfo, err := web.Filesystem.StatObject(bucket, object)
+	if err != nil {
+		writeWebErrorResponse(w, err.ToGoError())
+		return
+	}
+
+	offset, length := int64(0), objInfo.Size
+	statusCode := http.StatusOK
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+		hr, e := parseRequestRange(rangeHeader, objInfo.Size)
+		if e != nil {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		offset, length = hr.offset, hr.length
+		statusCode = http.StatusPartialContent
+		w.Header().Set("Content-Range", hr.contentRange(objInfo.Size))
+	}
+
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("ETag", "\""+objInfo.MD5Sum+"\"")
+	w.Header().Set("Last-Modified", objInfo.ModifiedTime.UTC().Format(http.TimeFormat))
+	w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filepath.Base(object)))
 
-	if _, err := web.Filesystem.GetObject(w, bucket, object, 0, 0); err != nil {
+	dw := &headerDeferredWriter{ResponseWriter: w, statusCode: statusCode}
+	if _, err = web.Filesystem.GetObject(dw, bucket, object, offset, length); err != nil {
 		writeWebErrorResponse(w, err.ToGoError())
 	}
 }
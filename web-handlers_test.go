@@ -0,0 +1,102 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestIsValidUploadID(t *testing.T) {
+	testCases := []struct {
+		uploadID string
+		valid    bool
+	}{
+		{"0123456789abcdef0123456789abcdef", true},
+		{"", false},
+		{"..", false},
+		{"../../etc/passwd", false},
+		{"0123456789ABCDEF0123456789ABCDEF", false},
+		{"0123456789abcdef", false},
+	}
+	for _, testCase := range testCases {
+		if got := isValidUploadID(testCase.uploadID); got != testCase.valid {
+			t.Errorf("isValidUploadID(%q) = %v, want %v", testCase.uploadID, got, testCase.valid)
+		}
+	}
+}
+
+func TestParseRequestRange(t *testing.T) {
+	const resourceSize = int64(1000)
+	testCases := []struct {
+		rangeHeader string
+		offset      int64
+		length      int64
+		wantErr     bool
+	}{
+		{"bytes=0-499", 0, 500, false},
+		{"bytes=500-999", 500, 500, false},
+		{"bytes=500-", 500, 500, false},
+		{"bytes=-500", 500, 500, false},
+		{"bytes=-2000", 0, 1000, false},
+		{"bytes=900-2000", 900, 100, false},
+		{"bytes=1000-1100", 0, 0, true},
+		{"bytes=500-100", 0, 0, true},
+		{"bytes=0-499,600-700", 0, 0, true},
+		{"bytes=", 0, 0, true},
+		{"junk=0-499", 0, 0, true},
+	}
+	for _, testCase := range testCases {
+		hr, e := parseRequestRange(testCase.rangeHeader, resourceSize)
+		if testCase.wantErr {
+			if e == nil {
+				t.Errorf("parseRequestRange(%q, %d) = %+v, want error", testCase.rangeHeader, resourceSize, hr)
+			}
+			continue
+		}
+		if e != nil {
+			t.Errorf("parseRequestRange(%q, %d) returned unexpected error: %v", testCase.rangeHeader, resourceSize, e)
+			continue
+		}
+		if hr.offset != testCase.offset || hr.length != testCase.length {
+			t.Errorf("parseRequestRange(%q, %d) = {offset:%d length:%d}, want {offset:%d length:%d}",
+				testCase.rangeHeader, resourceSize, hr.offset, hr.length, testCase.offset, testCase.length)
+		}
+	}
+}
+
+func TestMultipartUploadMetaRoundTrip(t *testing.T) {
+	uploadID, e := generateUploadID()
+	if e != nil {
+		t.Fatalf("generateUploadID failed: %v", e)
+	}
+	if e = os.MkdirAll(multipartStagingPath(uploadID), 0700); e != nil {
+		t.Fatalf("MkdirAll failed: %v", e)
+	}
+	defer os.RemoveAll(multipartStagingPath(uploadID))
+
+	if e = writeMultipartUploadMeta(uploadID, "mybucket", "myobject"); e != nil {
+		t.Fatalf("writeMultipartUploadMeta failed: %v", e)
+	}
+	meta, e := readMultipartUploadMeta(uploadID)
+	if e != nil {
+		t.Fatalf("readMultipartUploadMeta failed: %v", e)
+	}
+	if meta.BucketName != "mybucket" || meta.ObjectName != "myobject" {
+		t.Errorf("readMultipartUploadMeta() = %+v, want {mybucket myobject}", meta)
+	}
+}